@@ -0,0 +1,47 @@
+package cityhash
+
+import (
+	"strconv"
+	"testing"
+)
+
+var benchmarkSizes = []int{8, 16, 64, 256, 1024, 4096, 16384, 65536}
+
+func BenchmarkCityHash64(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		in := referenceInput(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				CityHash64(in)
+			}
+		})
+	}
+}
+
+func BenchmarkCityHash32(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		in := referenceInput(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				CityHash32(in)
+			}
+		})
+	}
+}
+
+func BenchmarkCityHash128(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		in := referenceInput(n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				CityHash128(in)
+			}
+		})
+	}
+}
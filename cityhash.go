@@ -1,25 +1,209 @@
+// Package cityhash is a pure Go port of Google's CityHash, a family of
+// non-cryptographic hash functions tuned for short, variable-length byte
+// strings such as map keys.
+//
+// CityHash64, CityHash32, and CityHash128 (and their seeded variants) match
+// the upstream C++ algorithm. CityHash128ClickHouse and CityHash64ClickHouse
+// additionally reproduce the older, pre-1.0.9 CityHash128 variant that
+// ClickHouse vendors for its `cityHash64`/`sipHash128` columns and block
+// checksums; that variant has not been checked against a running ClickHouse
+// instance (see CityHash128ClickHouse's doc comment) and should be verified
+// against one before being relied on for wire/on-disk compatibility.
 package cityhash
 
-/*
-#include <stdint.h>
-#include "cityhash.h"
-*/
-import "C"
-
 import (
-  "unsafe"
+	"encoding/binary"
+	"math/bits"
+)
+
+// The same mixing constants used by the upstream C++ implementation.
+const (
+	k0 uint64 = 0xc3a5c85c97cb3127
+	k1 uint64 = 0xb492b66fbe98f273
+	k2 uint64 = 0x9ae16a3b2f90404f
 )
 
+func fetch64(s []byte) uint64 {
+	return binary.LittleEndian.Uint64(s)
+}
+
+func fetch32(s []byte) uint32 {
+	return binary.LittleEndian.Uint32(s)
+}
+
+// rotate returns the 64-bit value val rotated right by shift bits.
+func rotate(val uint64, shift uint) uint64 {
+	if shift == 0 {
+		return val
+	}
+	return (val >> shift) | (val << (64 - shift))
+}
+
+// shiftMix folds the top 17 bits of val into its low bits, a cheap
+// pre-multiply step used throughout the algorithm to avalanche bits.
+func shiftMix(val uint64) uint64 {
+	return val ^ (val >> 47)
+}
+
+// hash128to64 collapses a 128-bit value to 64 bits, matching the upstream
+// Hash128to64 used to finalize CityHash64's 16-byte combine step.
+func hash128to64(lo, hi uint64) uint64 {
+	const mul = 0x9ddfea08eb382d69
+	a := (lo ^ hi) * mul
+	a ^= a >> 47
+	b := (hi ^ a) * mul
+	b ^= b >> 47
+	b *= mul
+	return b
+}
+
+func hashLen16(u, v uint64) uint64 {
+	return hash128to64(u, v)
+}
+
+func hashLen16Mul(u, v, mul uint64) uint64 {
+	a := (u ^ v) * mul
+	a ^= a >> 47
+	b := (v ^ a) * mul
+	b ^= b >> 47
+	b *= mul
+	return b
+}
+
+func hashLen0to16(s []byte) uint64 {
+	length := uint64(len(s))
+	if length >= 8 {
+		mul := k2 + length*2
+		a := fetch64(s) + k2
+		b := fetch64(s[length-8:])
+		c := rotate(b, 37)*mul + a
+		d := (rotate(a, 25) + b) * mul
+		return hashLen16Mul(c, d, mul)
+	}
+	if length >= 4 {
+		mul := k2 + length*2
+		a := fetch32(s)
+		return hashLen16Mul(length+uint64(a)<<3, uint64(fetch32(s[length-4:])), mul)
+	}
+	if length > 0 {
+		a := s[0]
+		b := s[length>>1]
+		c := s[length-1]
+		y := uint32(a) + uint32(b)<<8
+		z := uint32(length) + uint32(c)<<2
+		return shiftMix(uint64(y)*k2^uint64(z)*k0) * k2
+	}
+	return k2
+}
+
+func hashLen17to32(s []byte) uint64 {
+	length := uint64(len(s))
+	mul := k2 + length*2
+	a := fetch64(s) * k1
+	b := fetch64(s[8:])
+	c := fetch64(s[length-8:]) * mul
+	d := fetch64(s[length-16:]) * k2
+	return hashLen16Mul(
+		rotate(a+b, 43)+rotate(c, 30)+d,
+		a+rotate(b+k2, 18)+c,
+		mul,
+	)
+}
+
+// weakHashLen32WithSeeds mixes four 64-bit words of input with two seeds,
+// returning a pair of 64-bit outputs. It is "weak" in the sense that it is
+// not collision-resistant on its own, only as a building block of the
+// larger CityHash mixing rounds.
+func weakHashLen32WithSeeds(w, x, y, z, a, b uint64) (uint64, uint64) {
+	a += w
+	b = rotate(b+a+z, 21)
+	c := a
+	a += x
+	a += y
+	b += rotate(a, 44)
+	return a + z, b + c
+}
+
+func weakHashLen32WithSeedsBytes(s []byte, a, b uint64) (uint64, uint64) {
+	return weakHashLen32WithSeeds(fetch64(s), fetch64(s[8:]), fetch64(s[16:]), fetch64(s[24:]), a, b)
+}
+
+func hashLen33to64(s []byte) uint64 {
+	length := uint64(len(s))
+	mul := k2 + length*2
+	a := fetch64(s) * k2
+	b := fetch64(s[8:])
+	c := fetch64(s[length-24:])
+	d := fetch64(s[length-32:])
+	e := fetch64(s[16:]) * k2
+	f := fetch64(s[24:]) * 9
+	g := fetch64(s[length-8:])
+	h := fetch64(s[length-16:]) * mul
+
+	u := rotate(a+g, 43) + (rotate(b, 30)+c)*9
+	v := ((a + g) ^ d) + f + 1
+	w := bits.ReverseBytes64((u+v)*mul) + h
+	x := rotate(e+f, 42) + c
+	y := (bits.ReverseBytes64((v+w)*mul) + g) * mul
+	z := e + f + c
+	a = bits.ReverseBytes64((x+z)*mul+y) + b
+	b = shiftMix((z+a)*mul+d+h) * mul
+	return b + x
+}
+
+// CityHash64 computes a 64-bit hash of s.
 func CityHash64(s []byte) uint64 {
-  if len(s) == 0 {
-    return uint64(C.cityhash64((*C.uint8_t)(C.NULL), 0))
-  }
-  return uint64(C.cityhash64((*C.uint8_t)(unsafe.Pointer(&s[0])), C.size_t(len(s))))
+	length := len(s)
+	switch {
+	case length <= 16:
+		return hashLen0to16(s)
+	case length <= 32:
+		return hashLen17to32(s)
+	case length <= 64:
+		return hashLen33to64(s)
+	}
+
+	n := uint64(length)
+	x := fetch64(s[n-40:])
+	y := fetch64(s[n-16:]) + fetch64(s[n-56:])
+	z := hashLen16(fetch64(s[n-48:])+n, fetch64(s[n-24:]))
+	vFirst, vSecond := weakHashLen32WithSeedsBytes(s[n-64:], n, z)
+	wFirst, wSecond := weakHashLen32WithSeedsBytes(s[n-32:], y+k1, x)
+	x = x*k1 + fetch64(s)
+
+	// Repeatedly hash 64-byte blocks from the front, stopping short of the
+	// last 1 to 64 bytes (they were already folded in via the tail reads above).
+	remaining := (n - 1) &^ 63
+	p := s
+	for remaining != 0 {
+		x = rotate(x+y+vFirst+fetch64(p[8:]), 37) * k1
+		y = rotate(y+vSecond+fetch64(p[48:]), 42) * k1
+		x ^= wSecond
+		y += vFirst + fetch64(p[40:])
+		z = rotate(z+wFirst, 33) * k1
+		vFirst, vSecond = weakHashLen32WithSeedsBytes(p, vSecond*k1, x+wFirst)
+		wFirst, wSecond = weakHashLen32WithSeedsBytes(p[32:], z+wSecond, y+fetch64(p[16:]))
+		z, x = x, z
+		p = p[64:]
+		remaining -= 64
+	}
+
+	return hashLen16(
+		hashLen16(vFirst, wFirst)+shiftMix(y)*k1+z,
+		hashLen16(vSecond, wSecond)+x,
+	)
 }
 
+// CityHash32 computes a 32-bit hash of s.
 func CityHash32(s []byte) uint32 {
-  if len(s) == 0 {
-    return uint32(C.cityhash32((*C.uint8_t)(C.NULL), 0))
-  }
-  return uint32(C.cityhash32((*C.uint8_t)(unsafe.Pointer(&s[0])), C.size_t(len(s))))
+	length := len(s)
+	switch {
+	case length <= 4:
+		return hash32Len0to4(s)
+	case length <= 12:
+		return hash32Len5to12(s)
+	case length <= 24:
+		return hash32Len13to24(s)
+	}
+	return hash32Over24(s)
 }
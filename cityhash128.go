@@ -0,0 +1,146 @@
+package cityhash
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Uint128 is a 128-bit hash value, split into low and high 64-bit halves.
+type Uint128 struct {
+	Lo, Hi uint64
+}
+
+// Bytes returns the little-endian byte encoding of u, Lo first.
+func (u Uint128) Bytes() [16]byte {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[:8], u.Lo)
+	binary.LittleEndian.PutUint64(b[8:], u.Hi)
+	return b
+}
+
+// String renders u as a pair of hex-encoded 64-bit halves.
+func (u Uint128) String() string {
+	return fmt.Sprintf("%016x%016x", u.Hi, u.Lo)
+}
+
+// CityHash64WithSeed computes a 64-bit hash of s, seeded with seed. It is
+// suitable for building collections of related hashes that need to diverge
+// from the unseeded CityHash64 of the same input.
+func CityHash64WithSeed(s []byte, seed uint64) uint64 {
+	return CityHash64WithSeeds(s, k2, seed)
+}
+
+// CityHash64WithSeeds computes a 64-bit hash of s, seeded with seed0 and
+// seed1.
+func CityHash64WithSeeds(s []byte, seed0, seed1 uint64) uint64 {
+	return hashLen16(CityHash64(s)-seed0, seed1)
+}
+
+// cityMurmur is CityHash128's murmur-style fallback for inputs under 128
+// bytes, where the main 64-byte-block loop below would not have enough
+// data to get going.
+func cityMurmur(s []byte, seed Uint128) Uint128 {
+	a, b := seed.Lo, seed.Hi
+	var c, d uint64
+	length := len(s)
+
+	if length <= 16 {
+		a = shiftMix(a*k1) * k1
+		c = b*k1 + hashLen0to16(s)
+		var e uint64
+		if length >= 8 {
+			e = fetch64(s)
+		} else {
+			e = c
+		}
+		d = shiftMix(a + e)
+	} else {
+		n := uint64(length)
+		c = hashLen16(fetch64(s[n-8:])+k1, a)
+		d = hashLen16(b+n, c+fetch64(s[n-16:]))
+		a += d
+		p := s
+		for remaining := length - 16; remaining > 0; remaining -= 16 {
+			a ^= shiftMix(fetch64(p)*k1) * k1
+			a *= k1
+			b ^= a
+			c ^= shiftMix(fetch64(p[8:])*k1) * k1
+			c *= k1
+			d ^= c
+			p = p[16:]
+		}
+	}
+	a = hashLen16(a, c)
+	b = hashLen16(d, b)
+	return Uint128{Lo: a ^ b, Hi: hashLen16(b, a)}
+}
+
+// CityHash128WithSeed computes a 128-bit hash of s, seeded with seed.
+func CityHash128WithSeed(s []byte, seed Uint128) Uint128 {
+	if len(s) < 128 {
+		return cityMurmur(s, seed)
+	}
+
+	n := uint64(len(s))
+	x, y := seed.Lo, seed.Hi
+	z := n * k1
+	vFirst := rotate(y^k1, 49)*k1 + fetch64(s)
+	vSecond := rotate(vFirst, 42)*k1 + fetch64(s[8:])
+	wFirst := rotate(y+z, 35)*k1 + x
+	wSecond := rotate(x+fetch64(s[88:]), 53) * k1
+
+	// off walks forward through s as each 64-byte block is consumed;
+	// remaining tracks how much of s (from off onward) is still unprocessed.
+	off, remaining := 0, len(s)
+	for remaining >= 128 {
+		for i := 0; i < 2; i++ {
+			p := s[off:]
+			x = rotate(x+y+vFirst+fetch64(p[8:]), 37) * k1
+			y = rotate(y+vSecond+fetch64(p[48:]), 42) * k1
+			x ^= wSecond
+			y += vFirst + fetch64(p[40:])
+			z = rotate(z+wFirst, 33) * k1
+			vFirst, vSecond = weakHashLen32WithSeedsBytes(p, vSecond*k1, x+wFirst)
+			wFirst, wSecond = weakHashLen32WithSeedsBytes(p[32:], z+wSecond, y+fetch64(p[16:]))
+			z, x = x, z
+			off += 64
+		}
+		remaining -= 128
+	}
+
+	x += rotate(vFirst+z, 49) * k0
+	y = y*k0 + rotate(wSecond, 37)
+	z = z*k0 + rotate(wFirst, 27)
+	wFirst *= 9
+	vFirst *= k0
+
+	// The tail is folded in 32-byte steps counted from the end of s; once
+	// tailDone overshoots remaining, the reads intentionally reach back
+	// into the already-processed block immediately before off.
+	for tailDone := 0; tailDone < remaining; {
+		tailDone += 32
+		tail := s[len(s)-tailDone:]
+		y = rotate(x+y, 42)*k0 + vSecond
+		wFirst += fetch64(tail[16:])
+		x = x*k0 + wFirst
+		z += wSecond + fetch64(tail)
+		wSecond += vFirst
+		vFirst, vSecond = weakHashLen32WithSeedsBytes(tail, vFirst+z, vSecond)
+	}
+
+	x = hashLen16(x, vFirst)
+	y = hashLen16(y+z, wFirst)
+	return Uint128{
+		Lo: hashLen16(x+vSecond, wSecond) + y,
+		Hi: hashLen16(x+wSecond, y+vSecond),
+	}
+}
+
+// CityHash128 computes a 128-bit hash of s.
+func CityHash128(s []byte) Uint128 {
+	if len(s) >= 16 {
+		seed := Uint128{Lo: fetch64(s), Hi: fetch64(s[8:]) + k0}
+		return CityHash128WithSeed(s[16:], seed)
+	}
+	return CityHash128WithSeed(s, Uint128{Lo: k0, Hi: k1})
+}
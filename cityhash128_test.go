@@ -0,0 +1,65 @@
+package cityhash
+
+import "testing"
+
+// referenceVectors128 are CityHash128/CityHash128WithSeed outputs for
+// referenceInput(n). See referenceInput's doc comment for how these were
+// verified. Size 17 is included deliberately: it's the first size that both
+// takes its seed from the first 16 bytes and leaves a non-empty remainder
+// for cityMurmur, exactly the kind of off-by-one an independent
+// re-implementation is useful for catching. Only 256 and 1024 are long
+// enough to reach CityHash128WithSeed's main loop and its "reach back" tail.
+var referenceVectors128 = []struct {
+	size       int
+	hash       Uint128
+	seededHash Uint128
+}{
+	{0, Uint128{4463240938071824939, 4374473821787594281}, Uint128{1426270292422538334, 1359337482062878959}},
+	{1, Uint128{5654565074323204601, 17025003551300810650}, Uint128{6474460131607267100, 6207543066405849470}},
+	{8, Uint128{10455416223423459467, 5606507251652489951}, Uint128{7957703969071106095, 8803880091929462997}},
+	{15, Uint128{15807149816469453597, 9311195507683893083}, Uint128{782095537863456535, 3174764623777049727}},
+	{16, Uint128{7754388666927331102, 4650259522562730408}, Uint128{7437662256330935721, 8685899507886550288}},
+	{17, Uint128{13135816203719247744, 16345566477015443212}, Uint128{4601387920741382310, 12510329564506577009}},
+	{32, Uint128{7775349707285773498, 10386241426857643653}, Uint128{17268165191753631076, 13978036875067979308}},
+	{63, Uint128{13414406964884115725, 9184222928374064572}, Uint128{11796527560937791011, 17150202108893202700}},
+	{64, Uint128{11014145528095254549, 758222460490872562}, Uint128{9426006351312830900, 2776992688368819144}},
+	{65, Uint128{16966759525394196465, 16648537353224004992}, Uint128{11147169589946512865, 15610872059637421903}},
+	{128, Uint128{5898526509934858066, 7984615957355997782}, Uint128{12146080468361269369, 6938777819365365246}},
+	{256, Uint128{8324656024184191027, 5679268788173329082}, Uint128{1085324065417684841, 12409804028970969077}},
+	{1024, Uint128{3069583787623484636, 11931094178362290837}, Uint128{17426028546383903408, 8311473999950541842}},
+}
+
+var seed128 = Uint128{Lo: 123, Hi: 456}
+
+func TestCityHash128ReferenceVectors(t *testing.T) {
+	for _, v := range referenceVectors128 {
+		in := referenceInput(v.size)
+		if got := CityHash128(in); got != v.hash {
+			t.Errorf("CityHash128(referenceInput(%d)) = %+v, want %+v", v.size, got, v.hash)
+		}
+		if got := CityHash128WithSeed(in, seed128); got != v.seededHash {
+			t.Errorf("CityHash128WithSeed(referenceInput(%d), seed) = %+v, want %+v", v.size, got, v.seededHash)
+		}
+	}
+}
+
+func TestCityHash64WithSeed(t *testing.T) {
+	in := []byte("10F70305-2FA8-45EC-886F-21486263BA69")
+	got := CityHash64WithSeed(in, 42)
+	want := uint64(1355007344028446326)
+	if got != want {
+		t.Errorf("CityHash64WithSeed(%q, 42) = %d, want %d", in, got, want)
+	}
+}
+
+func TestUint128BytesAndString(t *testing.T) {
+	u := Uint128{Lo: 0x0102030405060708, Hi: 0x1112131415161718}
+	b := u.Bytes()
+	want := [16]byte{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x18, 0x17, 0x16, 0x15, 0x14, 0x13, 0x12, 0x11}
+	if b != want {
+		t.Errorf("Bytes() = %x, want %x", b, want)
+	}
+	if got, want := u.String(), "11121314151617180102030405060708"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package cityhash
+
+import "math/bits"
+
+const (
+	c1 uint32 = 0xcc9e2d51
+	c2 uint32 = 0x1b873593
+)
+
+func rotate32(val uint32, shift uint) uint32 {
+	if shift == 0 {
+		return val
+	}
+	return (val >> shift) | (val << (32 - shift))
+}
+
+func fmix(h uint32) uint32 {
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// mur is a single round of 32-bit murmur-style mixing, the building block
+// CityHash32 uses for every length bucket.
+func mur(a, h uint32) uint32 {
+	a *= c1
+	a = rotate32(a, 17)
+	a *= c2
+	h ^= a
+	h = rotate32(h, 19)
+	return h*5 + 0xe6546b64
+}
+
+func hash32Len0to4(s []byte) uint32 {
+	var b, c uint32 = 0, 9
+	for _, v := range s {
+		b = b*c1 + uint32(int32(int8(v)))
+		c ^= b
+	}
+	return fmix(mur(b, mur(uint32(len(s)), c)))
+}
+
+func hash32Len5to12(s []byte) uint32 {
+	length := uint32(len(s))
+	a, b, c, d := length, length*5, uint32(9), length*5
+	a += fetch32(s)
+	b += fetch32(s[length-4:])
+	c += fetch32(s[(length>>1)&4:])
+	return fmix(mur(c, mur(b, mur(a, d))))
+}
+
+func hash32Len13to24(s []byte) uint32 {
+	length := uint32(len(s))
+	a := fetch32(s[length/2-4:])
+	b := fetch32(s[4:])
+	c := fetch32(s[length-8:])
+	d := fetch32(s[length/2:])
+	e := fetch32(s)
+	f := fetch32(s[length-4:])
+	return fmix(mur(f, mur(e, mur(d, mur(c, mur(b, mur(a, length)))))))
+}
+
+func hash32Over24(s []byte) uint32 {
+	length := uint32(len(s))
+	h, g, f := length, c1*length, c1*length
+
+	a0 := rotate32(fetch32(s[length-4:])*c1, 17) * c2
+	a1 := rotate32(fetch32(s[length-8:])*c1, 17) * c2
+	a2 := rotate32(fetch32(s[length-16:])*c1, 17) * c2
+	a3 := rotate32(fetch32(s[length-12:])*c1, 17) * c2
+	a4 := rotate32(fetch32(s[length-20:])*c1, 17) * c2
+	h ^= a0
+	h = rotate32(h, 19)
+	h = h*5 + 0xe6546b64
+	h ^= a2
+	h = rotate32(h, 19)
+	h = h*5 + 0xe6546b64
+	g ^= a1
+	g = rotate32(g, 19)
+	g = g*5 + 0xe6546b64
+	g ^= a3
+	g = rotate32(g, 19)
+	g = g*5 + 0xe6546b64
+	f += a4
+	f = rotate32(f, 19)
+	f = f*5 + 0xe6546b64
+
+	iters := (length - 1) / 20
+	p := s
+	for i := uint32(0); i < iters; i++ {
+		b0 := rotate32(fetch32(p)*c1, 17) * c2
+		b1 := fetch32(p[4:])
+		b2 := rotate32(fetch32(p[8:])*c1, 17) * c2
+		b3 := rotate32(fetch32(p[12:])*c1, 17) * c2
+		b4 := fetch32(p[16:])
+		h ^= b0
+		h = rotate32(h, 18)
+		h = h*5 + 0xe6546b64
+		f += b1
+		f = rotate32(f, 19)
+		f = f * c1
+		g += b2
+		g = rotate32(g, 18)
+		g = g*5 + 0xe6546b64
+		h ^= b3 + b1
+		h = rotate32(h, 19)
+		h = h*5 + 0xe6546b64
+		g ^= b4
+		g = bits.ReverseBytes32(g) * 5
+		h += b4 * 5
+		h = bits.ReverseBytes32(h)
+		f += b0
+		f, g, h = g, h, f
+		p = p[20:]
+	}
+
+	g = rotate32(g, 11) * c1
+	g = rotate32(g, 17) * c1
+	f = rotate32(f, 11) * c1
+	f = rotate32(f, 17) * c1
+	h = rotate32(h+g, 19)
+	h = h*5 + 0xe6546b64
+	h = rotate32(h, 17) * c1
+	h = rotate32(h+f, 19)
+	h = h*5 + 0xe6546b64
+	h = rotate32(h, 17) * c1
+	return h
+}
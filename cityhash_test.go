@@ -7,7 +7,7 @@ import (
 type testdata struct {
 	input      string
 	cityHash64 uint64
-  cityHash32 uint32
+	cityHash32 uint32
 }
 
 var tests = []testdata{
@@ -40,3 +40,67 @@ func TestCityHash32(t *testing.T) {
 		}
 	}
 }
+
+// referenceInput deterministically fills a buffer of n bytes so the same
+// input is reproducible across runs and languages when cross-checking
+// independent implementations of CityHash against each other.
+//
+// Honest provenance, shared by every *Vectors table in this package's test
+// files: this sandbox has no network access, so none of those tables were
+// checked against a network-fetched copy of Google's city.cc (or, for
+// clickHouseVectors, a real ClickHouse instance). Instead they were produced
+// by independent, hand-transcribed implementations of the algorithm each
+// table targets — not derived from this package's code — that agree with
+// each other and with this package. That's good evidence the transcription
+// is faithful, but it is not the same as verifying against the real
+// upstream sources; re-run against those if they ever become reachable from
+// this environment. Each table's own comment notes anything specific to it.
+func referenceInput(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte((uint32(i) * 2654435761) >> 24)
+	}
+	return b
+}
+
+// referenceVectors are CityHash64/CityHash32 outputs for referenceInput(n)
+// at each size. See referenceInput's doc comment for how these were
+// verified. They were cross-checked with an independent C99 port and a
+// Python port of CityHash v1.1.
+var referenceVectors = []struct {
+	size       int
+	cityHash64 uint64
+	cityHash32 uint32
+}{
+	{0, 11160318154034397263, 3696677242},
+	{1, 13718060045003475796, 3232311124},
+	{8, 5010589790320547930, 3579250914},
+	{15, 7098468407091412568, 1041910387},
+	{16, 2175047195954233205, 2641470228},
+	{17, 6604678501320368951, 2994253493},
+	{32, 6933670674070886255, 21693494},
+	{63, 14494429829010464571, 3787210897},
+	{64, 8066348222733612633, 4018433445},
+	{65, 8924877542565525759, 3674366023},
+	{128, 18187275322686761910, 1893503358},
+	{256, 11763670587889259206, 1230792280},
+	{1024, 6098088589273229138, 668916999},
+}
+
+func TestCityHash64ReferenceVectors(t *testing.T) {
+	for _, v := range referenceVectors {
+		in := referenceInput(v.size)
+		if got := CityHash64(in); got != v.cityHash64 {
+			t.Errorf("CityHash64(referenceInput(%d)) = %d, want %d", v.size, got, v.cityHash64)
+		}
+	}
+}
+
+func TestCityHash32ReferenceVectors(t *testing.T) {
+	for _, v := range referenceVectors {
+		in := referenceInput(v.size)
+		if got := CityHash32(in); got != v.cityHash32 {
+			t.Errorf("CityHash32(referenceInput(%d)) = %d, want %d", v.size, got, v.cityHash32)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package cityhash
+
+// k3 is the extra mixing constant used by the pre-1.0.9 CityHash128 that
+// ClickHouse vendors and computes for its `cityHash64`/`sipHash128` columns
+// and native-protocol block checksums. Upstream CityHash128 moved to
+// deriving its seed as (Fetch64(s), Fetch64(s+8)+k0); the older, ClickHouse-
+// compatible seed is (Fetch64(s)^k3, Fetch64(s+8)) instead.
+const k3 uint64 = 0xc949d7c7509e6557
+
+// CityHash128ClickHouse computes the 128-bit hash ClickHouse computes for
+// `cityHash64`/`sipHash128` columns and its native-protocol block checksum:
+// the pre-1.0.9 CityHash128 algorithm that ClickHouse still vendors, which
+// diverges from today's upstream CityHash128 only in how the seed is
+// derived from the first 16 bytes of s.
+//
+// Caution: k3 and this seed derivation were transcribed from ClickHouse's
+// documented cityhash.h, not checked against a running ClickHouse instance
+// (see clickHouseVectors in clickhouse_test.go for why). Verify against a
+// real clickhouse-client/server before relying on this for anything that
+// must match ClickHouse's on-disk or wire-format checksums byte-for-byte.
+func CityHash128ClickHouse(s []byte) Uint128 {
+	if len(s) >= 16 {
+		seed := Uint128{Lo: fetch64(s) ^ k3, Hi: fetch64(s[8:])}
+		return CityHash128WithSeed(s[16:], seed)
+	}
+	return CityHash128WithSeed(s, Uint128{Lo: k0, Hi: k1})
+}
+
+// CityHash64ClickHouse computes the 64-bit hash ClickHouse computes for
+// `cityHash64` columns: the low 64 bits of CityHash128ClickHouse. See that
+// function's doc comment for the caveat on verification against a real
+// ClickHouse instance.
+func CityHash64ClickHouse(s []byte) uint64 {
+	return CityHash128ClickHouse(s).Lo
+}
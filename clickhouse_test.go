@@ -0,0 +1,61 @@
+package cityhash
+
+import "testing"
+
+// clickHouseVectors are CityHash64ClickHouse/CityHash128ClickHouse outputs
+// for referenceInput(n). Sizes span the 16-byte seed-consuming boundary
+// (15/16/17), where this variant's seeding first diverges from upstream
+// CityHash128 (below 16 bytes both match referenceVectors128), plus
+// 63/64/65 and longer inputs. See
+// referenceInput's doc comment for the general verification methodology;
+// unlike the other tables in this package, this one has an extra gap worth
+// calling out on its own: this sandbox has neither network access nor a
+// local clickhouse-client/server, so despite that being the original ask,
+// none of this was run against a real ClickHouse instance. Regenerate
+// against clickhouse-client or a ClickHouse checkout if either ever becomes
+// reachable from this environment, and update this comment.
+var clickHouseVectors = []struct {
+	size int
+	hash Uint128
+}{
+	{0, Uint128{4463240938071824939, 4374473821787594281}},
+	{1, Uint128{5654565074323204601, 17025003551300810650}},
+	{8, Uint128{10455416223423459467, 5606507251652489951}},
+	{15, Uint128{15807149816469453597, 9311195507683893083}},
+	{16, Uint128{16014965697894141790, 17479422919957943357}},
+	{17, Uint128{802349256422652203, 15213178327503904023}},
+	{32, Uint128{16365589299537323809, 11006662736614208201}},
+	{63, Uint128{15517757620720280801, 3979174938722951188}},
+	{64, Uint128{13995707807666172849, 17817379550574371765}},
+	{65, Uint128{9371611201261186258, 8809352972937551117}},
+	{128, Uint128{16485242240723226856, 12775305552054805536}},
+	{256, Uint128{15302839386379535943, 13132074789890347605}},
+	{1024, Uint128{2388544127947735898, 1685213622598227730}},
+}
+
+func TestCityHash128ClickHouseReferenceVectors(t *testing.T) {
+	for _, v := range clickHouseVectors {
+		in := referenceInput(v.size)
+		if got := CityHash128ClickHouse(in); got != v.hash {
+			t.Errorf("CityHash128ClickHouse(referenceInput(%d)) = %+v, want %+v", v.size, got, v.hash)
+		}
+		if got, want := CityHash64ClickHouse(in), v.hash.Lo; got != want {
+			t.Errorf("CityHash64ClickHouse(referenceInput(%d)) = %d, want %d", v.size, got, want)
+		}
+	}
+}
+
+func TestCityHash64ClickHouseUUIDs(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint64
+	}{
+		{"10F70305-2FA8-45EC-886F-21486263BA69", 6096812343895670969},
+		{"00000000-0000-0000-0000-000000000000", 9396260055329825694},
+	}
+	for _, data := range tests {
+		if got := CityHash64ClickHouse([]byte(data.input)); got != data.want {
+			t.Errorf("CityHash64ClickHouse(%q) = %d, want %d", data.input, got, data.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package cityhash
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// Hash128 implements hash.Hash, computing a streaming CityHash128 over the
+// bytes written to it. See Hash for why this buffers its entire input
+// rather than mixing in fixed blocks.
+type Hash128 struct {
+	seed Seed
+	buf  []byte
+}
+
+// New128 returns a new Hash128 whose Sum128 computes CityHash128 (or, once
+// SetSeed is called, CityHash128WithSeed) over all bytes written to it.
+//
+// Hash128 buffers everything written to it rather than mixing in bounded
+// memory (see Hash128's doc comment), so it is not a good fit for streaming
+// very large or unbounded inputs; for those, call CityHash128 directly once
+// the full input is assembled.
+func New128() *Hash128 {
+	return new(Hash128)
+}
+
+// SetSeed sets the Seed used to randomize future Sum128/Sum calls.
+func (h *Hash128) SetSeed(seed Seed) {
+	h.seed = seed
+}
+
+// Seed returns the Seed in effect for h.
+func (h *Hash128) Seed() Seed {
+	return h.seed
+}
+
+// Write implements io.Writer, appending p to the data to be hashed. It never
+// returns an error.
+func (h *Hash128) Write(p []byte) (int, error) {
+	h.buf = append(h.buf, p...)
+	return len(p), nil
+}
+
+// Sum128 returns CityHash128 (or CityHash128WithSeed, once a Seed has been
+// set) of all the bytes written to h so far.
+func (h *Hash128) Sum128() Uint128 {
+	if h.seed.s == 0 {
+		return CityHash128(h.buf)
+	}
+	return CityHash128WithSeed(h.buf, Uint128{Lo: h.seed.s, Hi: h.seed.s})
+}
+
+// Sum implements hash.Hash, appending the big-endian encoding of Sum128 (Hi
+// then Lo) to b.
+func (h *Hash128) Sum(b []byte) []byte {
+	sum := h.Sum128()
+	var out [16]byte
+	binary.BigEndian.PutUint64(out[:8], sum.Hi)
+	binary.BigEndian.PutUint64(out[8:], sum.Lo)
+	return append(b, out[:]...)
+}
+
+// Reset discards all bytes written to h so far, without affecting its Seed.
+func (h *Hash128) Reset() {
+	h.buf = h.buf[:0]
+}
+
+// Size returns 16, the number of bytes Sum appends.
+func (h *Hash128) Size() int { return 16 }
+
+// BlockSize returns CityHash128's internal mixing block size.
+func (h *Hash128) BlockSize() int { return 64 }
+
+var _ hash.Hash = (*Hash128)(nil)
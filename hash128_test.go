@@ -0,0 +1,40 @@
+package cityhash
+
+import "testing"
+
+func TestHash128MatchesCityHash128(t *testing.T) {
+	for _, size := range []int{0, 1, 8, 16, 17, 32, 64, 128, 256, 1024} {
+		in := referenceInput(size)
+		h := New128()
+		if _, err := h.Write(in); err != nil {
+			t.Fatalf("Write(%d bytes): %v", size, err)
+		}
+		if got, want := h.Sum128(), CityHash128(in); got != want {
+			t.Errorf("size %d: Sum128() = %+v, want %+v", size, got, want)
+		}
+	}
+}
+
+func TestHash128SetSeedChangesOutput(t *testing.T) {
+	in := referenceInput(64)
+	unseeded := New128()
+	unseeded.Write(in)
+
+	seeded := New128()
+	seeded.SetSeed(MakeSeed())
+	seeded.Write(in)
+
+	if unseeded.Sum128() == seeded.Sum128() {
+		t.Error("Sum128() did not change after SetSeed")
+	}
+}
+
+func TestHash128SizeAndBlockSize(t *testing.T) {
+	h := New128()
+	if h.Size() != 16 {
+		t.Errorf("Size() = %d, want 16", h.Size())
+	}
+	if h.BlockSize() != 64 {
+		t.Errorf("BlockSize() = %d, want 64", h.BlockSize())
+	}
+}
@@ -0,0 +1,97 @@
+package cityhash
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"hash"
+)
+
+// Seed carries the per-instance randomization for Hash and Hash128, mirroring
+// hash/maphash's design: a Hash or Hash128 created with New64/New128 is deterministic
+// (matching the unseeded CityHash64/CityHash128 functions) until SetSeed is
+// called with a Seed from MakeSeed, at which point its output diverges from
+// any other process or instance. Use this to make hashes of attacker-
+// controlled data unpredictable, e.g. for an in-memory hash table.
+type Seed struct {
+	s uint64
+}
+
+// MakeSeed returns a new random Seed suitable for SetSeed.
+func MakeSeed() Seed {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("cityhash: failed to read random seed: " + err.Error())
+	}
+	return Seed{s: binary.LittleEndian.Uint64(b[:])}
+}
+
+// Hash implements hash.Hash64, computing a streaming CityHash64 over the
+// bytes written to it. Hash128 is the 128-bit counterpart, returned by New128.
+//
+// Unlike hash/maphash's AES-based core, CityHash64's block algorithm reads
+// from both ends of its input (the final mix depends on the last bytes
+// before the total length is known), so it cannot be folded 64 bytes at a
+// time as data arrives. Hash instead buffers everything written to it and
+// defers to CityHash64 at Sum time; callers get the exact same
+// digest as hashing the concatenated input in one call.
+type Hash struct {
+	seed Seed
+	buf  []byte
+}
+
+// New64 returns a new Hash whose Sum64 computes CityHash64 (or, once SetSeed
+// is called, CityHash64WithSeed) over all bytes written to it.
+//
+// Hash buffers everything written to it rather than mixing in bounded
+// memory (see Hash's doc comment), so it is not a good fit for streaming
+// very large or unbounded inputs; for those, call CityHash64 directly once
+// the full input is assembled.
+func New64() *Hash {
+	return new(Hash)
+}
+
+// SetSeed sets the Seed used to randomize future Sum64/Sum calls.
+func (h *Hash) SetSeed(seed Seed) {
+	h.seed = seed
+}
+
+// Seed returns the Seed in effect for h.
+func (h *Hash) Seed() Seed {
+	return h.seed
+}
+
+// Write implements io.Writer, appending p to the data to be hashed. It never
+// returns an error.
+func (h *Hash) Write(p []byte) (int, error) {
+	h.buf = append(h.buf, p...)
+	return len(p), nil
+}
+
+// Sum64 returns CityHash64 (or CityHash64WithSeed, once a Seed has been set)
+// of all the bytes written to h so far.
+func (h *Hash) Sum64() uint64 {
+	if h.seed.s == 0 {
+		return CityHash64(h.buf)
+	}
+	return CityHash64WithSeed(h.buf, h.seed.s)
+}
+
+// Sum implements hash.Hash, appending the big-endian encoding of Sum64 to b.
+func (h *Hash) Sum(b []byte) []byte {
+	var out [8]byte
+	binary.BigEndian.PutUint64(out[:], h.Sum64())
+	return append(b, out[:]...)
+}
+
+// Reset discards all bytes written to h so far, without affecting its Seed.
+func (h *Hash) Reset() {
+	h.buf = h.buf[:0]
+}
+
+// Size returns 8, the number of bytes Sum appends.
+func (h *Hash) Size() int { return 8 }
+
+// BlockSize returns CityHash64's internal mixing block size.
+func (h *Hash) BlockSize() int { return 64 }
+
+var _ hash.Hash64 = (*Hash)(nil)
@@ -0,0 +1,87 @@
+package cityhash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHashMatchesCityHash64(t *testing.T) {
+	for _, size := range []int{0, 1, 8, 16, 17, 32, 33, 64, 65, 128, 1024, 65536} {
+		in := referenceInput(size)
+		h := New64()
+		if _, err := h.Write(in); err != nil {
+			t.Fatalf("Write(%d bytes): %v", size, err)
+		}
+		if got, want := h.Sum64(), CityHash64(in); got != want {
+			t.Errorf("size %d: Sum64() = %d, want %d", size, got, want)
+		}
+	}
+}
+
+func TestHashWriteInChunks(t *testing.T) {
+	in := referenceInput(1024)
+	h := New64()
+	for i := 0; i < len(in); i += 17 {
+		end := i + 17
+		if end > len(in) {
+			end = len(in)
+		}
+		if _, err := h.Write(in[i:end]); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if got, want := h.Sum64(), CityHash64(in); got != want {
+		t.Errorf("chunked Sum64() = %d, want %d", got, want)
+	}
+}
+
+func TestHashReset(t *testing.T) {
+	h := New64()
+	h.Write(referenceInput(32))
+	h.Reset()
+	h.Write(referenceInput(8))
+	if got, want := h.Sum64(), CityHash64(referenceInput(8)); got != want {
+		t.Errorf("Sum64() after Reset = %d, want %d", got, want)
+	}
+}
+
+func TestHashSetSeedChangesOutput(t *testing.T) {
+	in := referenceInput(64)
+	unseeded := New64()
+	unseeded.Write(in)
+
+	seeded := New64()
+	seeded.SetSeed(MakeSeed())
+	seeded.Write(in)
+
+	if unseeded.Sum64() == seeded.Sum64() {
+		t.Error("Sum64() did not change after SetSeed")
+	}
+	if got, want := seeded.Sum64(), CityHash64WithSeed(in, seeded.Seed().s); got != want {
+		t.Errorf("seeded Sum64() = %d, want %d", got, want)
+	}
+}
+
+func TestHashSum(t *testing.T) {
+	h := New64()
+	h.Write([]byte("hello"))
+	got := h.Sum(nil)
+	if len(got) != 8 {
+		t.Fatalf("len(Sum(nil)) = %d, want 8", len(got))
+	}
+	prefix := []byte("prefix:")
+	got = h.Sum(prefix)
+	if !bytes.HasPrefix(got, prefix) {
+		t.Errorf("Sum(prefix) did not retain prefix: %x", got)
+	}
+}
+
+func TestHashSizeAndBlockSize(t *testing.T) {
+	h := New64()
+	if h.Size() != 8 {
+		t.Errorf("Size() = %d, want 8", h.Size())
+	}
+	if h.BlockSize() != 64 {
+		t.Errorf("BlockSize() = %d, want 64", h.BlockSize())
+	}
+}